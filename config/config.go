@@ -0,0 +1,158 @@
+// Package config centralizes godhcperf's runtime settings so that they can
+// come from CLI flags, an optional YAML file, or built-in defaults instead
+// of being scattered across package-level constants.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds everything a worker needs to run a load generation pass.
+// It is loaded once in main and then passed around by value so that no
+// goroutine depends on mutable package-level state.
+type Config struct {
+	Interface string `yaml:"interface"`
+	Proto     string `yaml:"proto"`
+
+	WorkerCount int     `yaml:"worker_count"`
+	Rate        float64 `yaml:"rate"`
+	Burst       int     `yaml:"burst"`
+
+	// PoolSize is the number of raw sockets DHCPv4 workers share via the
+	// pool package, instead of each worker opening its own. Keeping this
+	// well below WorkerCount is what lets the rate limiter, not socket
+	// setup, drive the offered load.
+	PoolSize int `yaml:"pool_size"`
+
+	DiscoverTimeout time.Duration `yaml:"discover_timeout"`
+	RequestTimeout  time.Duration `yaml:"request_timeout"`
+	ReleaseTimeout  time.Duration `yaml:"release_timeout"`
+
+	// ServerAddr, if set, replaces nclient4.DefaultServers as the
+	// destination of the DHCPv4 scenarios' DISCOVER, REQUEST, and INFORM
+	// messages. Useful when testing a single relay or server directly. A
+	// renew's REQUEST still goes to the leasing server's identifier,
+	// RELEASE and DECLINE are still broadcast to nclient4.DefaultServers,
+	// and the DHCPv6 workers do not use it.
+	ServerAddr string `yaml:"server_addr"`
+
+	MetricsAddr string `yaml:"metrics_addr"`
+
+	// PprofAddr, if non-empty, serves net/http/pprof on this address.
+	PprofAddr string `yaml:"pprof_addr"`
+
+	LogLevel string `yaml:"log_level"`
+
+	// Scenarios is a weighted mix of scenario name to relative weight,
+	// e.g. {"dora": 70, "renew": 20, "decline": 10}. Workers sample from
+	// it on every iteration. See the scenario package for valid names.
+	Scenarios map[string]int `yaml:"scenarios"`
+}
+
+// Default returns the settings godhcperf used before this package existed,
+// preserved as the fallback for any value not set via flag or file.
+func Default() Config {
+	return Config{
+		Interface:       "eth0",
+		Proto:           "v4",
+		WorkerCount:     5,
+		Rate:            5,
+		Burst:           1,
+		PoolSize:        4,
+		DiscoverTimeout: 2 * time.Second,
+		RequestTimeout:  2 * time.Second,
+		ReleaseTimeout:  2 * time.Second,
+		MetricsAddr:     ":2112",
+		LogLevel:        "info",
+		Scenarios:       map[string]int{"dora": 100},
+	}
+}
+
+// Load builds a Config from, in increasing order of precedence: built-in
+// defaults, an optional "-config" YAML file, and CLI flags in args.
+func Load(args []string) (Config, error) {
+	cfg := Default()
+
+	configPath := peekConfigFlag(args)
+	if configPath != "" {
+		if err := cfg.mergeYAMLFile(configPath); err != nil {
+			return Config{}, fmt.Errorf("loading config file %s: %w", configPath, err)
+		}
+	}
+
+	fs := flag.NewFlagSet("godhcperf", flag.ExitOnError)
+	fs.StringVar(&configPath, "config", configPath, "path to a YAML config file")
+	fs.StringVar(&cfg.Interface, "iface", cfg.Interface, "network interface to send/receive DHCP traffic on")
+	fs.StringVar(&cfg.Proto, "proto", cfg.Proto, "protocol(s) to stress: v4, v6, or both")
+	fs.IntVar(&cfg.WorkerCount, "workers", cfg.WorkerCount, "number of concurrent workers per protocol")
+	fs.Float64Var(&cfg.Rate, "rate", cfg.Rate, "requests per second across all workers")
+	fs.IntVar(&cfg.Burst, "burst", cfg.Burst, "rate limiter burst size")
+	fs.IntVar(&cfg.PoolSize, "pool-size", cfg.PoolSize, "number of raw sockets DHCPv4 workers share")
+	fs.DurationVar(&cfg.DiscoverTimeout, "discover-timeout", cfg.DiscoverTimeout, "deadline for the DISCOVER/SOLICIT phase")
+	fs.DurationVar(&cfg.RequestTimeout, "request-timeout", cfg.RequestTimeout, "deadline for the REQUEST phase")
+	fs.DurationVar(&cfg.ReleaseTimeout, "release-timeout", cfg.ReleaseTimeout, "deadline for the RELEASE phase")
+	fs.StringVar(&cfg.ServerAddr, "server", cfg.ServerAddr, "send DHCPv4 DISCOVER/REQUEST/INFORM to this server instead of broadcasting (RELEASE and DECLINE are still broadcast)")
+	fs.StringVar(&cfg.MetricsAddr, "metrics-addr", cfg.MetricsAddr, "bind address for the Prometheus /metrics endpoint")
+	fs.StringVar(&cfg.PprofAddr, "pprof-addr", cfg.PprofAddr, "bind address for /debug/pprof (disabled if empty)")
+	fs.StringVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "log level: debug, info, warn, or error")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	switch cfg.Proto {
+	case "v4", "v6", "both":
+	default:
+		return Config{}, fmt.Errorf("invalid proto %q: must be v4, v6, or both", cfg.Proto)
+	}
+
+	return cfg, nil
+}
+
+// peekConfigFlag extracts "-config"/"--config" from args so Load can read
+// the file before binding the remaining flags against its values as
+// defaults. It scans args by hand rather than using flag.Parse, since
+// flag.Parse stops at the first flag it doesn't recognize and "-config"
+// is rarely first on a real command line; the full flag set in Load is
+// what reports real parse errors against the complete set.
+func peekConfigFlag(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "-config" || arg == "--config":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "-config="):
+			return strings.TrimPrefix(arg, "-config=")
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+	return ""
+}
+
+func (cfg *Config) mergeYAMLFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// yaml.Unmarshal adds to an existing map rather than replacing it, so
+	// clear the default Scenarios first and restore them only if the file
+	// doesn't set its own mix.
+	defaultScenarios := cfg.Scenarios
+	cfg.Scenarios = nil
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+	if cfg.Scenarios == nil {
+		cfg.Scenarios = defaultScenarios
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Load(nil) = %+v, want defaults %+v", cfg, Default())
+	}
+}
+
+func TestLoadYAMLOverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("rate: 50\nworker_count: 10\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Rate != 50 || cfg.WorkerCount != 10 {
+		t.Errorf("Load did not apply YAML overrides: %+v", cfg)
+	}
+	// Values absent from the file should fall through to the defaults.
+	if cfg.Burst != Default().Burst {
+		t.Errorf("cfg.Burst = %v, want default %v", cfg.Burst, Default().Burst)
+	}
+}
+
+func TestLoadFlagsOverrideYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("rate: 50\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := Load([]string{"-config", path, "-rate", "200"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Rate != 200 {
+		t.Errorf("cfg.Rate = %v, want flag override 200", cfg.Rate)
+	}
+}
+
+func TestLoadRejectsInvalidProto(t *testing.T) {
+	if _, err := Load([]string{"-proto", "v5"}); err == nil {
+		t.Fatal("Load with an invalid -proto: want error, got nil")
+	}
+}
+
+func TestPeekConfigFlagIgnoresUnknownFlags(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown flags before -config", []string{"-workers", "10", "-config", "path.yaml", "-rate", "5"}, "path.yaml"},
+		{"-config=value form", []string{"-workers", "10", "-config=path.yaml"}, "path.yaml"},
+		{"no -config", []string{"-workers", "10"}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := peekConfigFlag(tc.args); got != tc.want {
+				t.Errorf("peekConfigFlag(%v) = %q, want %q", tc.args, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// newLogger builds the structured logger used by the workers, honoring
+// cfg.LogLevel ("debug", "info", "warn", or "error"; unrecognized values
+// fall back to info).
+func newLogger(level string) *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: parseLogLevel(level),
+	}))
+}
+
+func parseLogLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -3,22 +3,23 @@ package main
 import (
 	"net"
 	"log"
+	"log/slog"
 	"crypto/rand"
 	"fmt"
 	"context"
-	"time"
 	"sync"
 	"os"
 	"os/signal"
 	"net/http"
+	"net/http/pprof"
 
 	"golang.org/x/time/rate"
 
-	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/cbertinato/godhcperf/config"
+	"github.com/cbertinato/godhcperf/pool"
+	"github.com/cbertinato/godhcperf/scenario"
 	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
 
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -40,131 +41,65 @@ func generateRandMAC() (net.HardwareAddr, error) {
 	return mac, nil
 }
 
-func setHWAddr(c *nclient4.Client, mac net.HardwareAddr) (err error) {
-	f := nclient4.WithHWAddr(mac)
-	err = f(c)
-	return
-}
-
-func newReleaseMessage(hwaddr net.HardwareAddr, clientIP net.IP, serverIP net.IP) (*dhcpv4.DHCPv4, error) {
-	return dhcpv4.New(
-		dhcpv4.WithHwAddr(hwaddr),
-		dhcpv4.WithMessageType(dhcpv4.MessageTypeRelease),
-		dhcpv4.WithClientIP(clientIP),
-		dhcpv4.WithServerIP(serverIP),
-	)
+func targetServer(cfg config.Config) net.Addr {
+	if cfg.ServerAddr == "" {
+		return nclient4.DefaultServers
+	}
+	return &net.UDPAddr{IP: net.ParseIP(cfg.ServerAddr), Port: 67}
 }
 
-func worker(c context.Context, limiter *rate.Limiter, wg *sync.WaitGroup) {
+func workerV4(c context.Context, cfg config.Config, logger *slog.Logger, limiter *rate.Limiter, client *pool.Client, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	conn, err := nclient4.NewRawUDPConn("eth0", 68) // broadcast
-	if err != nil {
-		log.Fatalf("unable to open a broadcasting socket: %w", err)
-		return
-	}
+	server := targetServer(cfg)
 
-	i, err := net.InterfaceByName("eth0")
+	mix, err := scenario.NewMix(cfg.Scenarios)
 	if err != nil {
-		log.Fatalf("unable to get interface information: %w", err)
+		logger.Error("unable to build scenario mix", "err", err)
 		return
 	}
 
-	client, _ := nclient4.NewWithConn(conn, i.HardwareAddr)
-
 	for {
 		select {
 		case <-c.Done():
 			return
 		default:
 			limiter.Wait(c)
+			runV4Iteration(cfg, logger, mix, client, server)
+		}
+	}
+}
 
-			randMAC, _ := generateRandMAC()
-			err := setHWAddr(client, randMAC)
-
-			conversation := make([]*dhcpv4.DHCPv4, 0)
-
-			// Discover
-			// RFC 2131, Section 4.4.1, Table 5 details what a DISCOVER packet should
-			// contain.
-			discover, err := dhcpv4.NewDiscovery(randMAC)
-			if err != nil {
-				err = fmt.Errorf("unable to create a discovery request: %w", err)
-				return
-			}
-
-			conversation = append(conversation, discover)
-
-			// Both server and client only get 2 seconds.
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			defer cancel()
-
-			log.Printf("Discover sent for MAC: %s\n", randMAC.String())
-			start := time.Now()
-			offer, err := client.SendAndRead(ctx, nclient4.DefaultServers, discover, nclient4.IsMessageType(dhcpv4.MessageTypeOffer))
-			discovers.Inc()
-		
-			// TODO: detect timeout
-			if err != nil {
-				log.Fatalf("got an error while the discovery request: %w", err)
-				return
-			}
-			offerLatency := float64(time.Since(start).Milliseconds())
-			discOfferLatency.Observe(offerLatency)
-			conversation = append(conversation, offer)
-
-			// Request and Ack
-			request, err := dhcpv4.NewRequestFromOffer(offer)
-			if err != nil {
-				log.Fatalf("error while creating request: %w", err)
-				return
-			}
-			conversation = append(conversation, request)
+// runV4Iteration drives a single scenario iteration with its own
+// DiscoverTimeout+RequestTimeout deadline. It's split out of workerV4 so
+// that cancel runs at the end of each iteration rather than piling up
+// deferred closures across the life of the worker goroutine.
+func runV4Iteration(cfg config.Config, logger *slog.Logger, mix *scenario.Mix, client *pool.Client, server net.Addr) {
+	randMAC, err := generateRandMAC()
+	if err != nil {
+		logger.Error("unable to generate a random MAC", "err", err)
+		return
+	}
 
-			log.Printf("Request for MAC: %s\n", randMAC.String())
-			start = time.Now()
-			ack, err := client.SendAndRead(ctx, nclient4.DefaultServers, request, nclient4.IsMessageType(dhcpv4.MessageTypeAck))
-			requests.Inc()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DiscoverTimeout+cfg.RequestTimeout)
+	defer cancel()
 
-			if err != nil {
-				log.Fatalf("error while sending request: %w", err)
-				return
-			}
-			ackLatency := float64(time.Since(start).Milliseconds())
-			requestAckLatency.Observe(ackLatency)
-			conversation = append(conversation, ack)
-
-			// send release message
-			release, err := newReleaseMessage(randMAC, offer.YourIPAddr, offer.ServerIdentifier())
-			if _, err := conn.WriteTo(release.ToBytes(), nclient4.DefaultServers); err != nil {
-				log.Fatalf("error writing packet to connection: %w", err)
-				return
-			}
-		}
+	s := mix.Pick()
+	logger.Debug("running scenario", "scenario", s.Name(), "mac", randMAC.String())
+	if _, err := s.Run(ctx, client, server, randMAC); err != nil {
+		logger.Error("scenario failed", "scenario", s.Name(), "mac", randMAC.String(), "server", server.String(), "err", err)
 	}
 }
 
-var (
-	discovers = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "discover_packets_sent",
-		Help: "Number of discover packets sent",
-	})
-	requests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "request_packets_sent",
-		Help: "Number of request packets sent",
-	})
-	discOfferLatency = promauto.NewHistogram(prometheus.HistogramOpts{
-		Name:      "discover_offer_latency",
-		Help:      "DISCOVERY-OFFER latency.",
-	})
-	requestAckLatency = promauto.NewHistogram(prometheus.HistogramOpts{
-		Name:      "request_ack_latency",
-		Help:      "REQUEST-ACK latency.",
-	})
-)
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
 
-func main () {
-	limiter := rate.NewLimiter(5, 1)
+	logger := newLogger(cfg.LogLevel)
+
+	limiter := rate.NewLimiter(rate.Limit(cfg.Rate), cfg.Burst)
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -185,15 +120,52 @@ func main () {
 		}
 	}()
 
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
 	go func() {
-		http.Handle("/metrics", promhttp.Handler())
-		http.ListenAndServe(":2112", nil)
+		if err := http.ListenAndServe(cfg.MetricsAddr, metricsMux); err != nil {
+			logger.Error("metrics server failed", "err", err)
+		}
 	}()
 
+	// pprof gets its own ServeMux instead of registering on
+	// http.DefaultServeMux, so it's reachable only via cfg.PprofAddr and
+	// not tagging along on the metrics listener above.
+	if cfg.PprofAddr != "" {
+		pprofMux := http.NewServeMux()
+		pprofMux.HandleFunc("/debug/pprof/", pprof.Index)
+		pprofMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		pprofMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		pprofMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		pprofMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+		go func() {
+			if err := http.ListenAndServe(cfg.PprofAddr, pprofMux); err != nil {
+				logger.Error("pprof server failed", "err", err)
+			}
+		}()
+	}
+
 	var wg sync.WaitGroup
-	wg.Add(5)
-	for i:=0; i < 5; i++ {
-		go worker(ctx, limiter, &wg)
+	if cfg.Proto == "v4" || cfg.Proto == "both" {
+		p, err := pool.New(cfg.Interface, cfg.PoolSize)
+		if err != nil {
+			log.Fatalf("unable to start DHCPv4 socket pool: %v", err)
+		}
+		defer p.Close()
+
+		client := pool.NewClient(p)
+
+		wg.Add(cfg.WorkerCount)
+		for i := 0; i < cfg.WorkerCount; i++ {
+			go workerV4(ctx, cfg, logger, limiter, client, &wg)
+		}
+	}
+	if cfg.Proto == "v6" || cfg.Proto == "both" {
+		wg.Add(cfg.WorkerCount)
+		for i := 0; i < cfg.WorkerCount; i++ {
+			go workerV6(ctx, cfg, logger, limiter, &wg)
+		}
 	}
 	wg.Wait()
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package pool
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// Client adapts a Pool to the same SendAndRead/SendMsg surface
+// nclient4.Client exposes (see scenario.DHCPv4Client), so scenario
+// implementations run unmodified whether they're handed a plain
+// *nclient4.Client or a pooled Client shared across many workers.
+type Client struct {
+	pool *Pool
+}
+
+// NewClient returns a Client that submits every transaction through
+// pool instead of opening its own socket.
+func NewClient(pool *Pool) *Client {
+	return &Client{pool: pool}
+}
+
+// SendAndRead submits packet to peer and blocks until a reply accepted
+// by matcher arrives or ctx is done; if ctx has no deadline, it gives up
+// with context.DeadlineExceeded after 2 seconds. matcher is plumbed into the Pool's
+// per-transaction goroutine via Request.Matcher, so replies that don't
+// satisfy it are discarded there and never reach this call - otherwise a
+// mismatching packet (a retransmitted OFFER arriving while this call
+// waits on an ACK, say) would consume the one delivery Submit ever
+// makes, stranding this call until ctx's deadline even though the real
+// reply arrives right after.
+func (c *Client) SendAndRead(ctx context.Context, peer net.Addr, packet *dhcpv4.DHCPv4, matcher nclient4.Matcher) (*dhcpv4.DHCPv4, error) {
+	deadline := time.Now().Add(2 * time.Second)
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	}
+
+	replyCh, err := c.pool.Submit(Request{Packet: packet, Peer: peer, Matcher: matcher, Deadline: deadline})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case reply, ok := <-replyCh:
+		if !ok {
+			return nil, context.DeadlineExceeded
+		}
+		return reply, nil
+	}
+}
+
+// SendMsg fires packet at the DHCP broadcast address without waiting for
+// a reply, for conversation steps like RELEASE and DECLINE that don't
+// expect one.
+func (c *Client) SendMsg(packet *dhcpv4.DHCPv4) error {
+	_, err := c.pool.nextConn().WriteTo(packet.ToBytes(), nclient4.DefaultServers)
+	return err
+}
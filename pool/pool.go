@@ -0,0 +1,116 @@
+// Package pool lets many concurrent DHCP conversations share a small
+// number of long-lived raw sockets instead of opening one per worker.
+// Each socket has a single reader goroutine that demultiplexes inbound
+// packets to the waiting transaction by DHCP transaction ID (xid), the
+// same approach connection-pooling proxies use to multiplex requests
+// over a handful of upstream connections.
+package pool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/cbertinato/godhcperf/rawconn"
+)
+
+// waiter is where Pool.read delivers the raw bytes of a reply matching a
+// transaction that's still outstanding.
+type waiter struct {
+	raw chan []byte
+}
+
+// Pool keeps n raw sockets open on an interface and routes inbound
+// packets to outstanding Requests by xid.
+type Pool struct {
+	conns []net.PacketConn
+
+	mu       sync.Mutex
+	inflight map[uint32]*waiter
+	next     uint32
+}
+
+// New opens n raw sockets on ifname (n is clamped to at least 1) and
+// starts a reader goroutine per socket.
+func New(ifname string, n int) (*Pool, error) {
+	if n < 1 {
+		n = 1
+	}
+
+	p := &Pool{inflight: make(map[uint32]*waiter)}
+	for i := 0; i < n; i++ {
+		conn, err := rawconn.NewRawConn(ifname, 68)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("pool: opening raw socket %d/%d on %s: %w", i+1, n, ifname, err)
+		}
+		p.conns = append(p.conns, conn)
+		go p.read(conn)
+	}
+	return p, nil
+}
+
+// Close shuts down every socket in the pool.
+func (p *Pool) Close() error {
+	var err error
+	for _, conn := range p.conns {
+		if cerr := conn.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// read is the single reader goroutine for one socket. It exits on the
+// first read error, such as the one returned once the socket is closed.
+func (p *Pool) read(conn net.PacketConn) {
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		xid, ok := transactionID(buf[:n])
+		if !ok {
+			continue
+		}
+
+		p.mu.Lock()
+		w, ok := p.inflight[xid]
+		p.mu.Unlock()
+		if !ok {
+			// No one is waiting on this xid anymore (or it was never
+			// one of ours); drop it.
+			continue
+		}
+
+		raw := make([]byte, n)
+		copy(raw, buf[:n])
+		select {
+		case w.raw <- raw:
+		default:
+			// The waiter already got a match on another reply; drop
+			// this one rather than block the reader goroutine.
+		}
+	}
+}
+
+// nextConn round-robins across the pool's sockets for outgoing writes.
+func (p *Pool) nextConn() net.PacketConn {
+	p.mu.Lock()
+	conn := p.conns[int(p.next)%len(p.conns)]
+	p.next++
+	p.mu.Unlock()
+	return conn
+}
+
+// transactionID extracts the xid field (bytes 4-7) from a raw DHCPv4
+// packet, per RFC 2131 section 2.
+func transactionID(b []byte) (uint32, bool) {
+	if len(b) < 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(b[4:8]), true
+}
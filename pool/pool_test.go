@@ -0,0 +1,110 @@
+package pool
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// discardConn is a net.PacketConn stub satisfying Pool.nextConn's
+// WriteTo call; Submit doesn't read from the pool's sockets directly
+// (Pool.read does that), so every other method goes unused here.
+type discardConn struct{}
+
+func (discardConn) ReadFrom(b []byte) (int, net.Addr, error)     { select {} }
+func (discardConn) WriteTo(b []byte, addr net.Addr) (int, error) { return len(b), nil }
+func (discardConn) Close() error                                 { return nil }
+func (discardConn) LocalAddr() net.Addr                          { return nil }
+func (discardConn) SetDeadline(t time.Time) error                { return nil }
+func (discardConn) SetReadDeadline(t time.Time) error            { return nil }
+func (discardConn) SetWriteDeadline(t time.Time) error           { return nil }
+
+func TestSubmitSkipsMismatchedReplyUntilMatcherIsSatisfied(t *testing.T) {
+	p := &Pool{inflight: make(map[uint32]*waiter), conns: []net.PacketConn{discardConn{}}}
+
+	xid := dhcpv4.TransactionID{0x11, 0x22, 0x33, 0x44}
+	req, err := dhcpv4.New(dhcpv4.WithTransactionID(xid))
+	if err != nil {
+		t.Fatalf("dhcpv4.New: %v", err)
+	}
+
+	reply, err := p.Submit(Request{
+		Packet:   req,
+		Peer:     &net.UDPAddr{},
+		Matcher:  nclient4.IsMessageType(dhcpv4.MessageTypeAck),
+		Deadline: time.Now().Add(2 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// A retransmitted OFFER sharing the same xid arrives first; Submit's
+	// goroutine should discard it and keep waiting rather than handing
+	// it back or giving up on the transaction.
+	offer, err := dhcpv4.New(dhcpv4.WithTransactionID(xid), dhcpv4.WithMessageType(dhcpv4.MessageTypeOffer))
+	if err != nil {
+		t.Fatalf("dhcpv4.New: %v", err)
+	}
+	ack, err := dhcpv4.New(dhcpv4.WithTransactionID(xid), dhcpv4.WithMessageType(dhcpv4.MessageTypeAck))
+	if err != nil {
+		t.Fatalf("dhcpv4.New: %v", err)
+	}
+
+	w := p.waiterFor(t, xid)
+	w.raw <- offer.ToBytes()
+	w.raw <- ack.ToBytes()
+
+	select {
+	case got, ok := <-reply:
+		if !ok {
+			t.Fatal("reply channel closed before the matching ACK arrived")
+		}
+		if got.MessageType() != dhcpv4.MessageTypeAck {
+			t.Errorf("reply message type = %s, want %s", got.MessageType(), dhcpv4.MessageTypeAck)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the matching reply")
+	}
+}
+
+// waiterFor looks up the Pool's waiter for xid the same way Pool.read
+// does, for tests that need to inject raw bytes as if a socket had
+// received them.
+func (p *Pool) waiterFor(t *testing.T, xid dhcpv4.TransactionID) *waiter {
+	t.Helper()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w, ok := p.inflight[binary.BigEndian.Uint32(xid[:])]
+	if !ok {
+		t.Fatalf("no waiter registered for xid %x", xid)
+	}
+	return w
+}
+
+func TestTransactionID(t *testing.T) {
+	cases := []struct {
+		name    string
+		packet  []byte
+		wantXID uint32
+		wantOK  bool
+	}{
+		{"too short", []byte{0x01, 0x02, 0x03}, 0, false},
+		{"well-formed header", []byte{0x01, 0x01, 0x06, 0x00, 0xde, 0xad, 0xbe, 0xef}, 0xdeadbeef, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			xid, ok := transactionID(tc.packet)
+			if ok != tc.wantOK {
+				t.Fatalf("transactionID(%x) ok = %v, want %v", tc.packet, ok, tc.wantOK)
+			}
+			if ok && xid != tc.wantXID {
+				t.Errorf("transactionID(%x) = %#x, want %#x", tc.packet, xid, tc.wantXID)
+			}
+		})
+	}
+}
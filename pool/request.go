@@ -0,0 +1,74 @@
+package pool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// Request is a single outstanding DHCP transaction to submit to a Pool.
+type Request struct {
+	// Packet is sent as-is to Peer; its TransactionID is what replies
+	// are correlated against.
+	Packet *dhcpv4.DHCPv4
+	Peer   net.Addr
+	// Matcher restricts which reply counts as the one the caller is
+	// waiting for. Replies with the right xid that Matcher rejects are
+	// discarded and the transaction keeps waiting for another one
+	// instead of handing back a mismatch. A nil Matcher accepts
+	// whatever comes back first.
+	Matcher  nclient4.Matcher
+	Deadline time.Time
+}
+
+// Submit sends req.Packet to req.Peer on one of the pool's sockets and
+// returns a channel that receives the first matching reply. The channel
+// is closed without a value if Deadline passes first.
+func (p *Pool) Submit(req Request) (<-chan *dhcpv4.DHCPv4, error) {
+	xid := binary.BigEndian.Uint32(req.Packet.TransactionID[:])
+
+	raw := make(chan []byte, 4)
+	p.mu.Lock()
+	p.inflight[xid] = &waiter{raw: raw}
+	p.mu.Unlock()
+
+	reply := make(chan *dhcpv4.DHCPv4, 1)
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			delete(p.inflight, xid)
+			p.mu.Unlock()
+		}()
+
+		timer := time.NewTimer(time.Until(req.Deadline))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+				close(reply)
+				return
+			case b := <-raw:
+				packet, err := dhcpv4.FromBytes(b)
+				if err != nil {
+					continue
+				}
+				if req.Matcher != nil && !req.Matcher(packet) {
+					continue
+				}
+				reply <- packet
+				return
+			}
+		}
+	}()
+
+	if _, err := p.nextConn().WriteTo(req.Packet.ToBytes(), req.Peer); err != nil {
+		return nil, fmt.Errorf("pool: writing packet: %w", err)
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,175 @@
+//go:build darwin || freebsd || openbsd
+
+package rawconn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mdlayher/raw"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	etherTypeIPv4  = 0x0800
+	protocolUDP    = 17
+	maxUDPReceived = 8192
+)
+
+var broadcastMAC = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// bsdRawConn implements net.PacketConn on top of github.com/mdlayher/raw,
+// the same approach the AdGuardHome nclient4 fork uses to support DHCP
+// clients on platforms without AF_PACKET. Since raw.Conn only speaks
+// Ethernet frames, this type hand-rolls the IPv4 and UDP headers that
+// AF_PACKET sockets get for free on Linux.
+type bsdRawConn struct {
+	conn   *raw.Conn
+	ifi    *net.Interface
+	srcMAC net.HardwareAddr
+	port   int
+}
+
+// newRawConn on the BSDs and macOS opens a raw Ethernet socket via
+// mdlayher/raw and wraps it so callers can treat it like any other
+// net.PacketConn sending/receiving DHCP UDP datagrams.
+func newRawConn(ifname string, port int) (net.PacketConn, error) {
+	ifi, err := net.InterfaceByName(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get interface %s: %w", ifname, err)
+	}
+
+	conn, err := raw.ListenPacket(ifi, etherTypeIPv4, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open raw socket on %s: %w", ifname, err)
+	}
+
+	return &bsdRawConn{
+		conn:   conn,
+		ifi:    ifi,
+		srcMAC: ifi.HardwareAddr,
+		port:   port,
+	}, nil
+}
+
+func (c *bsdRawConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	buf := make([]byte, maxUDPReceived)
+	for {
+		n, _, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		payload, srcIP, srcPort, ok := parseIPv4UDP(buf[:n])
+		if !ok {
+			continue
+		}
+
+		copied := copy(b, payload)
+		return copied, &net.UDPAddr{IP: srcIP, Port: srcPort}, nil
+	}
+}
+
+func (c *bsdRawConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return 0, fmt.Errorf("rawconn: unsupported address type %T", addr)
+	}
+
+	frame, err := buildIPv4UDPFrame(c.srcMAC, broadcastMAC, net.IPv4zero, udpAddr.IP, c.port, udpAddr.Port, b)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := c.conn.WriteTo(frame, &raw.Addr{HardwareAddr: broadcastMAC}); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+func (c *bsdRawConn) Close() error                       { return c.conn.Close() }
+func (c *bsdRawConn) LocalAddr() net.Addr                { return &net.UDPAddr{IP: net.IPv4zero, Port: c.port} }
+func (c *bsdRawConn) SetDeadline(t time.Time) error      { return c.conn.SetDeadline(t) }
+func (c *bsdRawConn) SetReadDeadline(t time.Time) error   { return c.conn.SetReadDeadline(t) }
+func (c *bsdRawConn) SetWriteDeadline(t time.Time) error  { return c.conn.SetWriteDeadline(t) }
+
+// buildIPv4UDPFrame assembles an Ethernet+IPv4+UDP frame carrying payload,
+// mirroring the framing AF_PACKET raw sockets perform implicitly on Linux.
+func buildIPv4UDPFrame(srcMAC, dstMAC net.HardwareAddr, srcIP, dstIP net.IP, srcPort, dstPort int, payload []byte) ([]byte, error) {
+	udpLen := 8 + len(payload)
+	udp := make([]byte, udpLen)
+	binary.BigEndian.PutUint16(udp[0:2], uint16(srcPort))
+	binary.BigEndian.PutUint16(udp[2:4], uint16(dstPort))
+	binary.BigEndian.PutUint16(udp[4:6], uint16(udpLen))
+	copy(udp[8:], payload)
+
+	ipHdr := ipv4.Header{
+		Version:  4,
+		Len:      ipv4.HeaderLen,
+		TotalLen: ipv4.HeaderLen + udpLen,
+		TTL:      64,
+		Protocol: protocolUDP,
+		Src:      srcIP.To4(),
+		Dst:      dstIP.To4(),
+	}
+	ipBytes, err := ipHdr.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal IPv4 header: %w", err)
+	}
+	binary.BigEndian.PutUint16(ipBytes[10:12], ipv4Checksum(ipBytes))
+
+	eth := make([]byte, 14)
+	copy(eth[0:6], dstMAC)
+	copy(eth[6:12], srcMAC)
+	binary.BigEndian.PutUint16(eth[12:14], etherTypeIPv4)
+
+	frame := append(eth, ipBytes...)
+	frame = append(frame, udp...)
+	return frame, nil
+}
+
+func parseIPv4UDP(frame []byte) (payload []byte, srcIP net.IP, srcPort int, ok bool) {
+	if len(frame) < 14+ipv4.HeaderLen+8 {
+		return nil, nil, 0, false
+	}
+	if binary.BigEndian.Uint16(frame[12:14]) != etherTypeIPv4 {
+		return nil, nil, 0, false
+	}
+
+	ipStart := 14
+	ihl := int(frame[ipStart]&0x0f) * 4
+	if frame[ipStart+9] != protocolUDP {
+		return nil, nil, 0, false
+	}
+
+	udpStart := ipStart + ihl
+	if len(frame) < udpStart+8 {
+		return nil, nil, 0, false
+	}
+
+	srcIP = net.IP(frame[ipStart+12 : ipStart+16])
+	srcPort = int(binary.BigEndian.Uint16(frame[udpStart : udpStart+2]))
+	udpLen := int(binary.BigEndian.Uint16(frame[udpStart+4 : udpStart+6]))
+	if udpStart+udpLen > len(frame) {
+		return nil, nil, 0, false
+	}
+
+	return frame[udpStart+8 : udpStart+udpLen], srcIP, srcPort, true
+}
+
+func ipv4Checksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i < len(b); i += 2 {
+		sum += uint32(b[i]) << 8
+		if i+1 < len(b) {
+			sum += uint32(b[i+1])
+		}
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
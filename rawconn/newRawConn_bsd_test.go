@@ -0,0 +1,55 @@
+//go:build darwin || freebsd || openbsd
+
+package rawconn
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestBuildAndParseIPv4UDPFrame(t *testing.T) {
+	srcMAC := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	dstMAC := broadcastMAC
+	srcIP := net.IPv4(192, 168, 1, 10)
+	dstIP := net.IPv4(255, 255, 255, 255)
+	payload := []byte("dhcp payload")
+
+	frame, err := buildIPv4UDPFrame(srcMAC, dstMAC, srcIP, dstIP, 68, 67, payload)
+	if err != nil {
+		t.Fatalf("buildIPv4UDPFrame: %v", err)
+	}
+
+	gotPayload, gotSrcIP, gotSrcPort, ok := parseIPv4UDP(frame)
+	if !ok {
+		t.Fatalf("parseIPv4UDP: ok = false, want true")
+	}
+	if !bytes.Equal(gotPayload, payload) {
+		t.Errorf("payload = %q, want %q", gotPayload, payload)
+	}
+	if !gotSrcIP.Equal(srcIP) {
+		t.Errorf("srcIP = %v, want %v", gotSrcIP, srcIP)
+	}
+	if gotSrcPort != 68 {
+		t.Errorf("srcPort = %d, want 68", gotSrcPort)
+	}
+}
+
+func TestParseIPv4UDPRejectsShortFrames(t *testing.T) {
+	if _, _, _, ok := parseIPv4UDP([]byte{0x01, 0x02, 0x03}); ok {
+		t.Error("parseIPv4UDP accepted a frame shorter than an Ethernet+IPv4+UDP header")
+	}
+}
+
+func TestParseIPv4UDPRejectsNonIPv4EtherType(t *testing.T) {
+	srcMAC := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	frame, err := buildIPv4UDPFrame(srcMAC, broadcastMAC, net.IPv4zero, net.IPv4bcast, 68, 67, []byte("x"))
+	if err != nil {
+		t.Fatalf("buildIPv4UDPFrame: %v", err)
+	}
+	frame[12], frame[13] = 0x08, 0x06 // ARP instead of IPv4
+
+	if _, _, _, ok := parseIPv4UDP(frame); ok {
+		t.Error("parseIPv4UDP accepted a non-IPv4 EtherType")
+	}
+}
@@ -0,0 +1,15 @@
+//go:build linux
+
+package rawconn
+
+import (
+	"net"
+
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// newRawConn on Linux uses nclient4's built-in AF_PACKET raw socket, which
+// is the fastest path available on this platform.
+func newRawConn(ifname string, port int) (net.PacketConn, error) {
+	return nclient4.NewRawUDPConn(ifname, port)
+}
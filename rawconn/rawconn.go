@@ -0,0 +1,20 @@
+// Package rawconn provides a platform-independent way to open a raw,
+// link-layer socket suitable for sending and receiving DHCP broadcast
+// traffic before an IP address has been assigned.
+//
+// Linux uses the kernel's AF_PACKET interface (via nclient4's built-in raw
+// socket support), while the BSDs and macOS route through
+// github.com/mdlayher/raw, which speaks BPF under the hood. Both backends
+// are exposed behind the single NewRawConn constructor below; which one is
+// compiled in is selected at build time by the newRawConn_*.go files in
+// this package.
+package rawconn
+
+import "net"
+
+// NewRawConn opens a raw socket bound to the named interface that can send
+// and receive DHCP packets addressed to port. The concrete implementation
+// is chosen at compile time based on GOOS.
+func NewRawConn(ifname string, port int) (net.PacketConn, error) {
+	return newRawConn(ifname, port)
+}
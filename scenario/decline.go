@@ -0,0 +1,70 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+)
+
+// declineReofferWindow is how long DeclineScenario waits after sending a
+// DECLINE before checking whether the server wrongly re-offers the same
+// address.
+const declineReofferWindow = 5 * time.Second
+
+// DeclineScenario runs a DORA handshake, sends a DECLINE for the offered
+// address (as if the client had detected an address conflict via ARP),
+// and then confirms the server doesn't hand the same address back out
+// within declineReofferWindow.
+type DeclineScenario struct{}
+
+func (DeclineScenario) Name() string { return "decline" }
+
+func (s DeclineScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (DeclineScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	decline, err := newDeclineMessage(mac, ack.YourIPAddr, ack.ServerIdentifier())
+	if err != nil {
+		return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, err
+	}
+	if err := client.SendMsg(decline); err != nil {
+		return Result{Outcome: RecordError(PhaseRelease, err), Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, err
+	}
+
+	reofferCtx, cancel := context.WithTimeout(context.Background(), declineReofferWindow)
+	defer cancel()
+
+	if reoffer, err := discover(reofferCtx, client, server, mac); err == nil && reoffer.YourIPAddr.Equal(ack.YourIPAddr) {
+		// The server handed the declined address straight back out: the
+		// behavior this scenario exists to catch. This isn't a DHCPNAK,
+		// so it gets its own outcome rather than overloading OutcomeNak.
+		return Result{Outcome: OutcomeReoffered, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, nil
+	}
+
+	return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, nil
+}
+
+func newDeclineMessage(mac net.HardwareAddr, clientIP, serverIP net.IP) (*dhcpv4.DHCPv4, error) {
+	return dhcpv4.New(
+		dhcpv4.WithHwAddr(mac),
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeDecline),
+		dhcpv4.WithOption(dhcpv4.OptRequestedIPAddress(clientIP)),
+		dhcpv4.WithServerIP(serverIP),
+	)
+}
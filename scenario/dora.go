@@ -0,0 +1,50 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+)
+
+// DORAScenario runs the DISCOVER/OFFER/REQUEST/ACK handshake and then
+// releases the lease, mirroring godhcperf's original worker behavior.
+type DORAScenario struct{}
+
+func (DORAScenario) Name() string { return "dora" }
+
+func (s DORAScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (DORAScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	if ack.MessageType() == dhcpv4.MessageTypeNak {
+		return Result{Outcome: OutcomeNak, Server: ack.ServerIdentifier()}, nil
+	}
+
+	leaseDuration := ack.IPAddressLeaseTime(0)
+
+	release, err := newReleaseMessage(mac, ack.YourIPAddr, ack.ServerIdentifier())
+	if err != nil {
+		return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier(), LeaseDuration: leaseDuration}, err
+	}
+	if err := client.SendMsg(release); err != nil {
+		return Result{Outcome: RecordError(PhaseRelease, err), Lease: ack.YourIPAddr, Server: ack.ServerIdentifier(), LeaseDuration: leaseDuration}, err
+	}
+
+	return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier(), LeaseDuration: leaseDuration}, nil
+}
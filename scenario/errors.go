@@ -0,0 +1,30 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	PhaseDiscover = "discover"
+	PhaseRequest  = "request"
+	PhaseRelease  = "release"
+)
+
+// RecordError classifies an error from a given conversation phase against
+// dhcp_errors_total/dhcp_timeouts_total and returns the Result.Outcome it
+// corresponds to. A DeadlineExceeded error (wrapped or not) means no
+// acceptable reply arrived before ctx's deadline and is counted with
+// reason "timeout"; every other error (connection refused, a failure to
+// build or write a packet, etc.) is counted with reason "io". Exported
+// so the DHCPv6 worker, which doesn't drive its conversation through a
+// Scenario, can still record against the same metrics.
+func RecordError(phase string, err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		timeoutsTotal.Inc()
+		errorsTotal.WithLabelValues(phase, "timeout").Inc()
+		return OutcomeTimeout
+	}
+	errorsTotal.WithLabelValues(phase, "io").Inc()
+	return OutcomeError
+}
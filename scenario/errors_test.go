@@ -0,0 +1,39 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordError(t *testing.T) {
+	cases := []struct {
+		name        string
+		err         error
+		wantOutcome string
+		wantReason  string
+	}{
+		{"timeout", context.DeadlineExceeded, OutcomeTimeout, "timeout"},
+		{"wrapped timeout", fmt.Errorf("discover: %w", context.DeadlineExceeded), OutcomeTimeout, "timeout"},
+		{"io error", errors.New("connection refused"), OutcomeError, "io"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := testutil.ToFloat64(errorsTotal.WithLabelValues(PhaseDiscover, tc.wantReason))
+
+			got := RecordError(PhaseDiscover, tc.err)
+			if got != tc.wantOutcome {
+				t.Errorf("RecordError(%v) = %q, want %q", tc.err, got, tc.wantOutcome)
+			}
+
+			after := testutil.ToFloat64(errorsTotal.WithLabelValues(PhaseDiscover, tc.wantReason))
+			if after != before+1 {
+				t.Errorf("dhcp_errors_total{phase=%s,reason=%s} = %v, want %v", PhaseDiscover, tc.wantReason, after, before+1)
+			}
+		})
+	}
+}
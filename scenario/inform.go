@@ -0,0 +1,53 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// InformScenario drives a DHCPINFORM conversation per RFC 2131 section
+// 4.4.3: a client that already has an address (here, one it just obtained
+// via DORA) asks the server for configuration parameters only, without
+// requesting a new lease.
+type InformScenario struct{}
+
+func (InformScenario) Name() string { return "inform" }
+
+func (s InformScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (InformScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	inform, err := dhcpv4.NewInform(mac, ack.YourIPAddr)
+	if err != nil {
+		return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, err
+	}
+
+	if _, err := client.SendAndRead(ctx, server, inform, nclient4.IsMessageType(dhcpv4.MessageTypeAck)); err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: ack.ServerIdentifier()}, err
+	}
+
+	return Result{
+		Outcome:       OutcomeOK,
+		Lease:         ack.YourIPAddr,
+		Server:        ack.ServerIdentifier(),
+		LeaseDuration: ack.IPAddressLeaseTime(0),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package scenario
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	runsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scenario_runs_total",
+		Help: "Number of scenario runs, labeled by scenario and outcome.",
+	}, []string{"scenario", "outcome"})
+
+	latency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "scenario_latency_seconds",
+		Help: "Total time to run a scenario to completion, labeled by scenario, responding server, and outcome.",
+	}, []string{"scenario", "server", "outcome"})
+
+	leaseDuration = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "dhcp_lease_duration_seconds",
+		Help: "Lease time granted in the most recent ACK, labeled by responding server.",
+	}, []string{"server"})
+
+	errorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dhcp_errors_total",
+		Help: "Number of DHCP conversation errors, labeled by phase and reason.",
+	}, []string{"phase", "reason"})
+
+	timeoutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "dhcp_timeouts_total",
+		Help: "Number of DHCP conversation steps that failed because ctx's deadline was exceeded.",
+	})
+)
+
+// observe records a completed scenario run against the shared
+// scenario_runs_total / scenario_latency_seconds metrics, and updates
+// dhcp_lease_duration_seconds if res carries a lease.
+func observe(name string, res Result, start time.Time) {
+	server := res.Server.String()
+	if res.Server == nil {
+		server = ""
+	}
+
+	runsTotal.WithLabelValues(name, res.Outcome).Inc()
+	latency.WithLabelValues(name, server, res.Outcome).Observe(time.Since(start).Seconds())
+
+	if res.LeaseDuration > 0 {
+		leaseDuration.WithLabelValues(server).Set(res.LeaseDuration.Seconds())
+	}
+}
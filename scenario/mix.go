@@ -0,0 +1,67 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var errNoScenarios = errors.New("scenario: no scenarios with a positive weight configured")
+
+func unknownScenarioError(name string) error {
+	return fmt.Errorf("scenario: unknown scenario %q", name)
+}
+
+// Mix samples a Scenario per iteration according to a set of relative
+// weights, e.g. {dora: 70, renew: 20, decline: 10}.
+type Mix struct {
+	scenarios []Scenario
+	weights   []int
+	total     int
+}
+
+// registry maps every scenario name a config.Config.Scenarios entry can
+// reference to its implementation.
+var registry = map[string]Scenario{
+	DORAScenario{}.Name():       DORAScenario{},
+	RenewScenario{}.Name():      RenewScenario{},
+	RebindScenario{}.Name():     RebindScenario{},
+	InformScenario{}.Name():     InformScenario{},
+	DeclineScenario{}.Name():    DeclineScenario{},
+	StarvationScenario{}.Name(): StarvationScenario{},
+}
+
+// NewMix builds a Mix from a name->weight map such as the one loaded from
+// config.Config.Scenarios. Weights of zero or negative are ignored.
+func NewMix(weights map[string]int) (*Mix, error) {
+	m := &Mix{}
+	for name, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		s, ok := registry[name]
+		if !ok {
+			return nil, unknownScenarioError(name)
+		}
+		m.scenarios = append(m.scenarios, s)
+		m.weights = append(m.weights, weight)
+		m.total += weight
+	}
+	if m.total == 0 {
+		return nil, errNoScenarios
+	}
+	return m, nil
+}
+
+// Pick samples a Scenario proportionally to its configured weight.
+func (m *Mix) Pick() Scenario {
+	n := rand.Intn(m.total)
+	for i, w := range m.weights {
+		if n < w {
+			return m.scenarios[i]
+		}
+		n -= w
+	}
+	// Unreachable so long as total is the sum of weights.
+	return m.scenarios[len(m.scenarios)-1]
+}
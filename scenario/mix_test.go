@@ -0,0 +1,47 @@
+package scenario
+
+import "testing"
+
+func TestNewMixIgnoresNonPositiveWeights(t *testing.T) {
+	m, err := NewMix(map[string]int{"dora": 1, "renew": 0, "decline": -5})
+	if err != nil {
+		t.Fatalf("NewMix: %v", err)
+	}
+	if len(m.scenarios) != 1 || m.scenarios[0].Name() != "dora" {
+		t.Fatalf("NewMix kept non-positive weights: %+v", m.scenarios)
+	}
+}
+
+func TestNewMixNoPositiveWeights(t *testing.T) {
+	if _, err := NewMix(map[string]int{"dora": 0}); err != errNoScenarios {
+		t.Fatalf("NewMix with no positive weights = %v, want errNoScenarios", err)
+	}
+}
+
+func TestNewMixUnknownScenario(t *testing.T) {
+	if _, err := NewMix(map[string]int{"bogus": 1}); err == nil {
+		t.Fatal("NewMix with an unknown scenario name: want error, got nil")
+	}
+}
+
+func TestMixPickRespectsWeights(t *testing.T) {
+	m, err := NewMix(map[string]int{"dora": 70, "decline": 30})
+	if err != nil {
+		t.Fatalf("NewMix: %v", err)
+	}
+
+	counts := map[string]int{}
+	const iterations = 10000
+	for i := 0; i < iterations; i++ {
+		counts[m.Pick().Name()]++
+	}
+
+	for _, name := range []string{"dora", "decline"} {
+		if counts[name] == 0 {
+			t.Errorf("scenario %q was never picked across %d iterations", name, iterations)
+		}
+	}
+	if counts["dora"] <= counts["decline"] {
+		t.Errorf("dora (weight 70) was picked %d times, decline (weight 30) %d times; expected dora to dominate", counts["dora"], counts["decline"])
+	}
+}
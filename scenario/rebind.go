@@ -0,0 +1,60 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// RebindScenario runs a DORA handshake and then broadcasts the REQUEST a
+// client sends once its T2 timer fires without having heard back from its
+// renewal attempts, and validates that some server on the segment ACKs it.
+type RebindScenario struct{}
+
+func (RebindScenario) Name() string { return "rebind" }
+
+func (s RebindScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (RebindScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	rebindRequest, err := newRenewRequest(mac, ack.YourIPAddr)
+	if err != nil {
+		return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, err
+	}
+
+	// T2 rebinds are broadcast, since the client has given up on its
+	// original leasing server and is hoping any server on the segment
+	// recognizes the lease.
+	rebindAck, err := client.SendAndRead(ctx, server, rebindRequest, nclient4.IsMessageType(dhcpv4.MessageTypeAck))
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: ack.ServerIdentifier()}, err
+	}
+
+	if rebindAck.MessageType() == dhcpv4.MessageTypeNak {
+		return Result{Outcome: OutcomeNak, Server: rebindAck.ServerIdentifier()}, nil
+	}
+
+	return Result{
+		Outcome:       OutcomeOK,
+		Lease:         rebindAck.YourIPAddr,
+		Server:        rebindAck.ServerIdentifier(),
+		LeaseDuration: rebindAck.IPAddressLeaseTime(0),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// RenewScenario runs a DORA handshake and then immediately follows up with
+// the unicast REQUEST a client sends to its lease's server once its T1
+// timer fires, and validates that the server ACKs it.
+type RenewScenario struct{}
+
+func (RenewScenario) Name() string { return "renew" }
+
+func (s RenewScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (RenewScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	renewRequest, err := newRenewRequest(mac, ack.YourIPAddr)
+	if err != nil {
+		return Result{Outcome: OutcomeOK, Lease: ack.YourIPAddr, Server: ack.ServerIdentifier()}, err
+	}
+
+	// T1 renewals are unicast directly to the leasing server, not
+	// broadcast, since the client already has a usable address.
+	unicastServer := &net.UDPAddr{IP: ack.ServerIdentifier(), Port: 67}
+	renewAck, err := client.SendAndRead(ctx, unicastServer, renewRequest, nclient4.IsMessageType(dhcpv4.MessageTypeAck))
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: ack.ServerIdentifier()}, err
+	}
+
+	if renewAck.MessageType() == dhcpv4.MessageTypeNak {
+		return Result{Outcome: OutcomeNak, Server: renewAck.ServerIdentifier()}, nil
+	}
+
+	return Result{
+		Outcome:       OutcomeOK,
+		Lease:         renewAck.YourIPAddr,
+		Server:        renewAck.ServerIdentifier(),
+		LeaseDuration: renewAck.IPAddressLeaseTime(0),
+	}, nil
+}
+
+// newRenewRequest builds the unicast REQUEST a renewing client sends with
+// ciaddr set and no server identifier option, per RFC 2131 section 4.4.5.
+func newRenewRequest(mac net.HardwareAddr, leaseIP net.IP) (*dhcpv4.DHCPv4, error) {
+	return dhcpv4.New(
+		dhcpv4.WithHwAddr(mac),
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeRequest),
+		dhcpv4.WithClientIP(leaseIP),
+	)
+}
@@ -0,0 +1,89 @@
+// Package scenario pulls the DHCP conversations that godhcperf can drive
+// out of worker into pluggable, independently testable units. A worker
+// picks a Scenario for each iteration (see Mix) and simply calls Run.
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/insomniacslk/dhcp/dhcpv4"
+	"github.com/insomniacslk/dhcp/dhcpv4/nclient4"
+)
+
+// Result describes the outcome of a single Scenario.Run call, so that
+// callers can record it against Prometheus without the scenario having to
+// know anything about metrics itself.
+type Result struct {
+	// Outcome is one of "ok", "timeout", "error", "nak", or "reoffered".
+	Outcome string
+	// Lease is the address obtained during the conversation, if any.
+	Lease net.IP
+	// Server is the responding server's identifier (the ServerIdentifier
+	// option of the last OFFER or ACK received), if a conversation got
+	// that far. It's
+	// what lets operators compare behavior across multiple relays or
+	// servers answering on the same segment.
+	Server net.IP
+	// LeaseDuration is the lease time offered in the ACK, if any.
+	LeaseDuration time.Duration
+}
+
+const (
+	OutcomeOK        = "ok"
+	OutcomeTimeout   = "timeout"
+	OutcomeError     = "error"
+	OutcomeNak       = "nak"
+	OutcomeReoffered = "reoffered"
+)
+
+// DHCPv4Client is the subset of nclient4.Client's surface a Scenario
+// needs. It exists so that scenarios can run unmodified whether they're
+// handed a plain *nclient4.Client (one raw socket per worker) or a
+// *pool.Client (many workers sharing a handful of pooled sockets).
+type DHCPv4Client interface {
+	SendAndRead(ctx context.Context, peer net.Addr, packet *dhcpv4.DHCPv4, matcher nclient4.Matcher) (*dhcpv4.DHCPv4, error)
+	SendMsg(packet *dhcpv4.DHCPv4) error
+}
+
+// Scenario is a single DHCP conversation a worker can drive against a
+// server: DORA, renew, rebind, inform, decline, or starvation.
+type Scenario interface {
+	// Name identifies the scenario for metrics and the weighted mix
+	// config (config.Config.Scenarios).
+	Name() string
+
+	// Run drives the conversation to completion using client, sending to
+	// server and using mac as the client's hardware address.
+	Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error)
+}
+
+// discover performs the common DISCOVER/OFFER exchange that every
+// scenario in this package starts from.
+func discover(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (*dhcpv4.DHCPv4, error) {
+	discoverMsg, err := dhcpv4.NewDiscovery(mac)
+	if err != nil {
+		return nil, err
+	}
+	return client.SendAndRead(ctx, server, discoverMsg, nclient4.IsMessageType(dhcpv4.MessageTypeOffer))
+}
+
+// requestFromOffer performs the REQUEST/ACK exchange that follows an
+// OFFER in the initial DORA handshake.
+func requestFromOffer(ctx context.Context, client DHCPv4Client, server net.Addr, offer *dhcpv4.DHCPv4) (*dhcpv4.DHCPv4, error) {
+	request, err := dhcpv4.NewRequestFromOffer(offer)
+	if err != nil {
+		return nil, err
+	}
+	return client.SendAndRead(ctx, server, request, nclient4.IsMessageType(dhcpv4.MessageTypeAck))
+}
+
+func newReleaseMessage(mac net.HardwareAddr, clientIP, serverIP net.IP) (*dhcpv4.DHCPv4, error) {
+	return dhcpv4.New(
+		dhcpv4.WithHwAddr(mac),
+		dhcpv4.WithMessageType(dhcpv4.MessageTypeRelease),
+		dhcpv4.WithClientIP(clientIP),
+		dhcpv4.WithServerIP(serverIP),
+	)
+}
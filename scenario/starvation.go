@@ -0,0 +1,41 @@
+package scenario
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// StarvationScenario runs a DORA handshake and deliberately never releases
+// the lease, so that a mix weighted heavily toward it can be used to
+// measure how a server behaves as its address pool is exhausted.
+type StarvationScenario struct{}
+
+func (StarvationScenario) Name() string { return "starvation" }
+
+func (s StarvationScenario) Run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	start := time.Now()
+	res, err := s.run(ctx, client, server, mac)
+	observe(s.Name(), res, start)
+	return res, err
+}
+
+func (StarvationScenario) run(ctx context.Context, client DHCPv4Client, server net.Addr, mac net.HardwareAddr) (Result, error) {
+	offer, err := discover(ctx, client, server, mac)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseDiscover, err)}, err
+	}
+
+	ack, err := requestFromOffer(ctx, client, server, offer)
+	if err != nil {
+		return Result{Outcome: RecordError(PhaseRequest, err), Server: offer.ServerIdentifier()}, err
+	}
+
+	// Deliberately no RELEASE: that's the point of this scenario.
+	return Result{
+		Outcome:       OutcomeOK,
+		Lease:         ack.YourIPAddr,
+		Server:        ack.ServerIdentifier(),
+		LeaseDuration: ack.IPAddressLeaseTime(0),
+	}, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/cbertinato/godhcperf/config"
+	"github.com/cbertinato/godhcperf/scenario"
+	"github.com/insomniacslk/dhcp/dhcpv6"
+	"github.com/insomniacslk/dhcp/dhcpv6/nclient6"
+	"github.com/insomniacslk/dhcp/iana"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+func generateRandDUIDLL() (*dhcpv6.DUIDLL, error) {
+	mac, err := generateRandMAC()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dhcpv6.DUIDLL{
+		HWType:        iana.HWTypeEthernet,
+		LinkLayerAddr: mac,
+	}, nil
+}
+
+// newReleaseFromReply builds a RELEASE message for the lease granted in
+// reply (the REPLY returned from Client.Request), mirroring the way
+// dhcpv6.NewRequestFromAdvertise builds a REQUEST from an ADVERTISE.
+// nclient6.Client has no built-in helper for RELEASE, so this worker
+// constructs and sends it directly via Client.SendAndRead.
+func newReleaseFromReply(reply *dhcpv6.Message) (*dhcpv6.Message, error) {
+	release, err := dhcpv6.NewMessage()
+	if err != nil {
+		return nil, err
+	}
+	release.MessageType = dhcpv6.MessageTypeRelease
+
+	cid := reply.GetOneOption(dhcpv6.OptionClientID)
+	if cid == nil {
+		return nil, fmt.Errorf("client ID cannot be nil in REPLY when building RELEASE")
+	}
+	release.AddOption(cid)
+
+	sid := reply.GetOneOption(dhcpv6.OptionServerID)
+	if sid == nil {
+		return nil, fmt.Errorf("server ID cannot be nil in REPLY when building RELEASE")
+	}
+	release.AddOption(sid)
+
+	iaNA := reply.Options.OneIANA()
+	if iaNA == nil {
+		return nil, fmt.Errorf("IA_NA cannot be nil in REPLY when building RELEASE")
+	}
+	release.AddOption(iaNA)
+
+	release.AddOption(dhcpv6.OptElapsedTime(0))
+
+	return release, nil
+}
+
+func workerV6(c context.Context, cfg config.Config, logger *slog.Logger, limiter *rate.Limiter, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	client, err := nclient6.New(cfg.Interface)
+	if err != nil {
+		logger.Error("unable to create a DHCPv6 client", "iface", cfg.Interface, "err", err)
+		return
+	}
+	defer client.Close()
+
+	for {
+		select {
+		case <-c.Done():
+			return
+		default:
+			limiter.Wait(c)
+			runV6Iteration(cfg, logger, client)
+		}
+	}
+}
+
+// runV6Iteration drives a single SOLICIT/REQUEST/RELEASE conversation with
+// its own DiscoverTimeout deadline. It's split out of workerV6 so that
+// cancel runs at the end of each iteration rather than piling up deferred
+// closures across the life of the worker goroutine.
+func runV6Iteration(cfg config.Config, logger *slog.Logger, client *nclient6.Client) {
+	duid, err := generateRandDUIDLL()
+	if err != nil {
+		logger.Error("unable to generate a DUID-LL", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DiscoverTimeout)
+	defer cancel()
+
+	logger.Debug("solicit sent", "duid", duid.String())
+	start := time.Now()
+	advertise, err := client.Solicit(ctx, dhcpv6.WithClientID(duid))
+	solicitsSent.Inc()
+
+	if err != nil {
+		logger.Error("solicit failed", "phase", scenario.PhaseDiscover, "duid", duid.String(), "outcome", scenario.RecordError(scenario.PhaseDiscover, err), "err", err)
+		return
+	}
+	solicitAdvertiseLatency.Observe(float64(time.Since(start).Milliseconds()))
+
+	logger.Debug("request sent", "duid", duid.String())
+	start = time.Now()
+	reply, err := client.Request(ctx, advertise)
+	requestsSentV6.Inc()
+
+	if err != nil {
+		logger.Error("request failed", "phase", scenario.PhaseRequest, "duid", duid.String(), "outcome", scenario.RecordError(scenario.PhaseRequest, err), "err", err)
+		return
+	}
+	requestReplyLatencyV6.Observe(float64(time.Since(start).Milliseconds()))
+
+	release, err := newReleaseFromReply(reply)
+	if err != nil {
+		logger.Error("unable to build RELEASE", "duid", duid.String(), "err", err)
+		return
+	}
+	if _, err := client.SendAndRead(ctx, client.RemoteAddr(), release, nclient6.IsMessageType(dhcpv6.MessageTypeReply)); err != nil {
+		logger.Error("release failed", "phase", scenario.PhaseRelease, "duid", duid.String(), "outcome", scenario.RecordError(scenario.PhaseRelease, err), "err", err)
+	}
+}
+
+var (
+	solicitsSent = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "solicit_packets_sent",
+		Help: "Number of DHCPv6 solicit packets sent",
+	})
+	requestsSentV6 = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "request_packets_sent_v6",
+		Help: "Number of DHCPv6 request packets sent",
+	})
+	solicitAdvertiseLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "solicit_advertise_latency",
+		Help: "SOLICIT-ADVERTISE latency.",
+	})
+	requestReplyLatencyV6 = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "request_reply_latency_v6",
+		Help: "REQUEST-REPLY latency (DHCPv6).",
+	})
+)